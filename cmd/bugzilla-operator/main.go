@@ -0,0 +1,35 @@
+// Command bugzilla-operator runs the Bugzilla triage bot described by the
+// config file given as its first argument.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"k8s.io/klog"
+
+	"github.com/mfojtik/bugzilla-operator/pkg/operator"
+	"github.com/mfojtik/bugzilla-operator/pkg/operator/config"
+)
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintf(os.Stderr, "usage: %s <config.yaml>\n", os.Args[0])
+		os.Exit(1)
+	}
+
+	cfg, err := config.Load(os.Args[1])
+	if err != nil {
+		klog.Fatalf("failed to load config: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := operator.Run(ctx, cfg); err != nil {
+		klog.Fatalf("operator exited with error: %v", err)
+	}
+}
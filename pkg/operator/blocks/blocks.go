@@ -0,0 +1,168 @@
+// Package blocks renders a report's bug entries as paginated Slack Block
+// Kit blocks with per-bug action buttons, replacing the single text blob
+// the `report <job>` command used to post.
+package blocks
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	slackgo "github.com/slack-go/slack"
+)
+
+// PageSize is the number of bug entries shown per page.
+const PageSize = 10
+
+var bugIDPattern = regexp.MustCompile(`[Bb]ug #?(\d{5,8})`)
+
+// Entry is one bug's worth of report text, with the bug ID extracted from
+// it when one could be found.
+type Entry struct {
+	BugID int
+	Text  string
+}
+
+// Split breaks a report's text into per-bug entries on blank lines, the
+// separator every reporter in this tree already uses between bugs.
+func Split(report string) []Entry {
+	var entries []Entry
+	for _, para := range strings.Split(strings.TrimSpace(report), "\n\n") {
+		para = strings.TrimSpace(para)
+		if len(para) == 0 {
+			continue
+		}
+		entry := Entry{Text: para}
+		if m := bugIDPattern.FindStringSubmatch(para); m != nil {
+			fmt.Sscanf(m[1], "%d", &entry.BugID)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// Page renders entries[page*PageSize:(page+1)*PageSize] as Block Kit
+// blocks for job, with Prev/Next pagination buttons and, for every entry
+// whose bug ID was recognized, a row of per-bug action buttons.
+func Page(job string, entries []Entry, page int) []slackgo.Block {
+	result := []slackgo.Block{
+		slackgo.NewSectionBlock(slackgo.NewTextBlockObject(slackgo.MarkdownType, fmt.Sprintf("*%s*", job), false, false), nil, nil),
+	}
+
+	if page < 0 {
+		page = 0
+	}
+	start := page * PageSize
+	if start > len(entries) {
+		start = len(entries)
+	}
+	end := start + PageSize
+	if end > len(entries) {
+		end = len(entries)
+	}
+
+	for _, e := range entries[start:end] {
+		result = append(result,
+			slackgo.NewDividerBlock(),
+			slackgo.NewSectionBlock(slackgo.NewTextBlockObject(slackgo.MarkdownType, e.Text, false, false), nil, nil),
+		)
+		if e.BugID == 0 {
+			continue
+		}
+		result = append(result, slackgo.NewActionBlock(
+			fmt.Sprintf("bug-%d", e.BugID),
+			slackgo.NewButtonBlockElement("reset-stale", EncodeValue(job, e.BugID, "reset-stale"), slackgo.NewTextBlockObject(slackgo.PlainTextType, "Reset stale", false, false)),
+			slackgo.NewButtonBlockElement("close", EncodeValue(job, e.BugID, "close"), slackgo.NewTextBlockObject(slackgo.PlainTextType, "Close", false, false)),
+			slackgo.NewButtonBlockElement("assign-to-me", EncodeValue(job, e.BugID, "assign-to-me"), slackgo.NewTextBlockObject(slackgo.PlainTextType, "Assign to me", false, false)),
+		))
+	}
+
+	result = append(result, slackgo.NewContextBlock("",
+		slackgo.NewTextBlockObject(slackgo.MarkdownType, fmt.Sprintf("Page %d of %d", page+1, numPages(len(entries))), false, false)))
+
+	var nav []slackgo.BlockElement
+	if start > 0 {
+		nav = append(nav, slackgo.NewButtonBlockElement("prev-page", EncodePage(job, page-1), slackgo.NewTextBlockObject(slackgo.PlainTextType, "Prev", false, false)))
+	}
+	if end < len(entries) {
+		nav = append(nav, slackgo.NewButtonBlockElement("next-page", EncodePage(job, page+1), slackgo.NewTextBlockObject(slackgo.PlainTextType, "Next", false, false)))
+	}
+	if len(nav) > 0 {
+		result = append(result, slackgo.NewActionBlock("pagination", nav...))
+	}
+	return result
+}
+
+func numPages(n int) int {
+	if n == 0 {
+		return 1
+	}
+	return (n + PageSize - 1) / PageSize
+}
+
+// EntryStore keeps the most recently rendered entries for each job, so the
+// interactive callback handler can rebuild a later page of a report when
+// Prev/Next is clicked without re-running the report itself.
+type EntryStore struct {
+	mu      sync.Mutex
+	entries map[string][]Entry
+}
+
+// NewEntryStore returns an empty EntryStore.
+func NewEntryStore() *EntryStore {
+	return &EntryStore{entries: map[string][]Entry{}}
+}
+
+// Save records entries as the current entry list for job, replacing
+// whatever was saved for it before.
+func (s *EntryStore) Save(job string, entries []Entry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[job] = entries
+}
+
+// Load returns the entries last saved for job, and whether any were found.
+func (s *EntryStore) Load(job string) ([]Entry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries, ok := s.entries[job]
+	return entries, ok
+}
+
+// EncodeValue packs job/bugID/action into a button value the interactive
+// callback handler can parse back out with DecodeValue.
+func EncodeValue(job string, bugID int, action string) string {
+	return fmt.Sprintf("bug|%s|%d|%s", job, bugID, action)
+}
+
+// DecodeValue reverses EncodeValue.
+func DecodeValue(value string) (job string, bugID int, action string, ok bool) {
+	parts := strings.SplitN(value, "|", 4)
+	if len(parts) != 4 || parts[0] != "bug" {
+		return "", 0, "", false
+	}
+	if _, err := fmt.Sscanf(parts[2], "%d", &bugID); err != nil {
+		return "", 0, "", false
+	}
+	return parts[1], bugID, parts[3], true
+}
+
+// EncodePage packs job/page into a pagination button value.
+func EncodePage(job string, page int) string {
+	return fmt.Sprintf("page|%s|%d", job, page)
+}
+
+// DecodePage reverses EncodePage. It rejects a negative page number: Page
+// never encodes one, so one in the wild only comes from a forged or
+// corrupted button value.
+func DecodePage(value string) (job string, page int, ok bool) {
+	parts := strings.SplitN(value, "|", 3)
+	if len(parts) != 3 || parts[0] != "page" {
+		return "", 0, false
+	}
+	if _, err := fmt.Sscanf(parts[2], "%d", &page); err != nil || page < 0 {
+		return "", 0, false
+	}
+	return parts[1], page, true
+}
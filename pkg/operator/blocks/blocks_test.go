@@ -0,0 +1,119 @@
+package blocks
+
+import (
+	"testing"
+
+	slackgo "github.com/slack-go/slack"
+)
+
+func TestSplitExtractsBugID(t *testing.T) {
+	report := "Bug 1234567 is stale\nline two\n\nNo bug here\n\nBug #7654321 needs triage"
+	entries := Split(report)
+	if len(entries) != 3 {
+		t.Fatalf("Split() = %d entries, want 3", len(entries))
+	}
+	if entries[0].BugID != 1234567 {
+		t.Fatalf("entries[0].BugID = %d, want 1234567", entries[0].BugID)
+	}
+	if entries[1].BugID != 0 {
+		t.Fatalf("entries[1].BugID = %d, want 0 (no bug number in text)", entries[1].BugID)
+	}
+	if entries[2].BugID != 7654321 {
+		t.Fatalf("entries[2].BugID = %d, want 7654321", entries[2].BugID)
+	}
+}
+
+func TestSplitTrimsAndSkipsBlankParagraphs(t *testing.T) {
+	report := "\n\n  Bug 1111111 first  \n\n\n\nBug 2222222 second\n\n"
+	entries := Split(report)
+	if len(entries) != 2 {
+		t.Fatalf("Split() = %d entries, want 2", len(entries))
+	}
+	if entries[0].Text != "Bug 1111111 first" {
+		t.Fatalf("entries[0].Text = %q, not trimmed", entries[0].Text)
+	}
+}
+
+func TestEncodeDecodeValueRoundTrip(t *testing.T) {
+	value := EncodeValue("weekly-report", 1234567, "close")
+	job, bugID, action, ok := DecodeValue(value)
+	if !ok {
+		t.Fatalf("DecodeValue(%q) ok = false, want true", value)
+	}
+	if job != "weekly-report" || bugID != 1234567 || action != "close" {
+		t.Fatalf("DecodeValue(%q) = (%q, %d, %q), want (weekly-report, 1234567, close)", value, job, bugID, action)
+	}
+}
+
+func TestDecodeValueRejectsGarbage(t *testing.T) {
+	for _, value := range []string{"", "bug|only-two|parts", "page|weekly-report|0", "bug|job|notanumber|close"} {
+		if _, _, _, ok := DecodeValue(value); ok {
+			t.Fatalf("DecodeValue(%q) ok = true, want false", value)
+		}
+	}
+}
+
+func TestEncodeDecodePageRoundTrip(t *testing.T) {
+	value := EncodePage("weekly-report", 3)
+	job, page, ok := DecodePage(value)
+	if !ok || job != "weekly-report" || page != 3 {
+		t.Fatalf("DecodePage(%q) = (%q, %d, %v), want (weekly-report, 3, true)", value, job, page, ok)
+	}
+}
+
+func TestDecodePageRejectsNegativeAndGarbage(t *testing.T) {
+	for _, value := range []string{"page|weekly-report|-1", "page|weekly-report|notanumber", "bug|weekly-report|0"} {
+		if _, _, ok := DecodePage(value); ok {
+			t.Fatalf("DecodePage(%q) ok = true, want false", value)
+		}
+	}
+}
+
+func TestPageClampsNegativePage(t *testing.T) {
+	entries := []Entry{{BugID: 1000000, Text: "entry"}}
+	// Page must not be reachable with a negative page from DecodePage, but
+	// clamp defensively anyway so a forged/direct caller can't drive the
+	// slice bounds below zero and panic.
+	if got := Page("weekly-report", entries, -1); len(got) == 0 {
+		t.Fatalf("Page(job, entries, -1) returned no blocks")
+	}
+}
+
+func TestPagePaginatesAndAddsNavButtons(t *testing.T) {
+	entries := make([]Entry, PageSize+1)
+	for i := range entries {
+		entries[i] = Entry{BugID: 1000000 + i, Text: "entry"}
+	}
+
+	firstPage := Page("weekly-report", entries, 0)
+	if len(firstPage) == 0 {
+		t.Fatalf("Page() returned no blocks")
+	}
+	if !blockSetContainsButton(firstPage, EncodePage("weekly-report", 1)) {
+		t.Fatalf("first page missing a Next button to page 1")
+	}
+	if blockSetContainsButton(firstPage, EncodePage("weekly-report", -1)) {
+		t.Fatalf("first page must not have a Prev button")
+	}
+
+	secondPage := Page("weekly-report", entries, 1)
+	if !blockSetContainsButton(secondPage, EncodePage("weekly-report", 0)) {
+		t.Fatalf("second page missing a Prev button to page 0")
+	}
+}
+
+func blockSetContainsButton(blockSet []slackgo.Block, value string) bool {
+	for _, b := range blockSet {
+		action, ok := b.(*slackgo.ActionBlock)
+		if !ok {
+			continue
+		}
+		for _, el := range action.Elements.ElementSet {
+			button, ok := el.(*slackgo.ButtonBlockElement)
+			if ok && button.Value == value {
+				return true
+			}
+		}
+	}
+	return false
+}
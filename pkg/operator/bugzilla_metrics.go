@@ -0,0 +1,36 @@
+package operator
+
+import (
+	"github.com/eparis/bugzilla"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/mfojtik/bugzilla-operator/pkg/cache"
+	"github.com/mfojtik/bugzilla-operator/pkg/operator/metrics"
+)
+
+// instrumentedBugzillaClient wraps a cache.BugzillaClient to record calls
+// against the bugzilla_operator_bugzilla_call_* metrics. It embeds the real
+// client so every method this tree can't see (cache.BugzillaClient's full
+// method set isn't part of this checkout) still passes straight through;
+// only the calls overridden below are actually observed.
+type instrumentedBugzillaClient struct {
+	cache.BugzillaClient
+}
+
+// instrumentBugzillaClient wraps c so the instrumented calls on every client
+// newBugzillaClient hands out -- to controllers, reports and the interactive
+// handler alike -- are observed in one place, rather than requiring each
+// call site to instrument itself.
+func instrumentBugzillaClient(c cache.BugzillaClient) cache.BugzillaClient {
+	return &instrumentedBugzillaClient{BugzillaClient: c}
+}
+
+func (i *instrumentedBugzillaClient) UpdateBug(id int, update bugzilla.BugUpdate) error {
+	timer := prometheus.NewTimer(metrics.BugzillaCallDuration.WithLabelValues("UpdateBug"))
+	err := i.BugzillaClient.UpdateBug(id, update)
+	timer.ObserveDuration()
+	if err != nil {
+		metrics.BugzillaCallErrors.WithLabelValues("UpdateBug").Inc()
+	}
+	return err
+}
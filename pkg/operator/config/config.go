@@ -0,0 +1,147 @@
+// Package config defines the operator's YAML configuration file and the
+// OperatorConfig struct every controller, reporter and Slack command in
+// this tree is constructed with.
+package config
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"gopkg.in/yaml.v2"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// OperatorConfig is the root of the operator's YAML configuration file.
+type OperatorConfig struct {
+	// CachePath, if set, persists the Bugzilla response cache to disk
+	// across restarts.
+	CachePath string `yaml:"cachePath"`
+
+	// Credentials holds the operator's Bugzilla and Slack secrets, stored
+	// base64-encoded so they aren't readable straight out of the YAML file.
+	Credentials Credentials `yaml:"credentials"`
+
+	// SlackAdminChannel receives startup/shutdown notices, controller
+	// warnings and anything posted by the admin Slack commands.
+	SlackAdminChannel string `yaml:"slackAdminChannel"`
+
+	// DisabledControllers lists controller names (as returned by each
+	// controller's Name(), e.g. "NewBugController") that are registered
+	// but never run.
+	DisabledControllers []string `yaml:"disabledControllers"`
+
+	// Components is the default set of Bugzilla components reports run
+	// against when a schedule entry doesn't list its own.
+	Components ComponentSet `yaml:"components"`
+
+	// Schedules lists the cron-scheduled reports this operator runs.
+	Schedules []ScheduleEntry `yaml:"schedules"`
+
+	// Groups maps a group name (e.g. "admins") to the Slack user IDs/names
+	// that belong to it, gating both the admin Slack commands and the
+	// interactive callback's button actions.
+	Groups map[string][]string `yaml:"groups"`
+
+	// PluginDir, if set, is scanned for -buildmode=plugin .so files to load
+	// as additional plugin.Plugin implementations.
+	PluginDir string `yaml:"pluginDir"`
+
+	// MetricsAddress, if set, serves Prometheus metrics on this address.
+	MetricsAddress string `yaml:"metricsAddress"`
+
+	// SlackInteractiveAddress, if set, serves Slack's interactive-message
+	// callback on this address.
+	SlackInteractiveAddress string `yaml:"slackInteractiveAddress"`
+
+	// ShutdownGracePeriod bounds how long Run waits for in-flight jobs to
+	// finish once its context is canceled before giving up on them. Zero
+	// (including an unset YAML key) means Run picks its own default rather
+	// than returning immediately.
+	ShutdownGracePeriod time.Duration `yaml:"shutdownGracePeriod"`
+}
+
+// ScheduleEntry is one cron-scheduled group of reports.
+type ScheduleEntry struct {
+	// SlackChannel is where this schedule's reports are posted.
+	SlackChannel string `yaml:"slackChannel"`
+	// Reports lists report names (as used by `report <job>`) to run on
+	// this schedule.
+	Reports []string `yaml:"reports"`
+	// Components overrides OperatorConfig.Components for these reports.
+	Components []string `yaml:"components"`
+	// When is the list of cron expressions to run these reports on.
+	When []string `yaml:"when"`
+}
+
+// Credentials holds base64-encoded secrets, decoded on demand by the
+// DecodedXxx accessors. The raw struct still carries the secrets in
+// recoverable form; OperatorConfig.Anonymize clears it.
+type Credentials struct {
+	SlackToken             string `yaml:"slackToken"`
+	SlackVerificationToken string `yaml:"slackVerificationToken"`
+	BugzillaAPIKey         string `yaml:"bugzillaAPIKey"`
+	BugzillaUsername       string `yaml:"bugzillaUsername"`
+	BugzillaPassword       string `yaml:"bugzillaPassword"`
+}
+
+func (c Credentials) DecodedSlackToken() string { return decodeBase64(c.SlackToken) }
+func (c Credentials) DecodedSlackVerificationToken() string {
+	return decodeBase64(c.SlackVerificationToken)
+}
+func (c Credentials) DecodedAPIKey() string   { return decodeBase64(c.BugzillaAPIKey) }
+func (c Credentials) DecodedUsername() string { return decodeBase64(c.BugzillaUsername) }
+func (c Credentials) DecodedPassword() string { return decodeBase64(c.BugzillaPassword) }
+
+func decodeBase64(s string) string {
+	if len(s) == 0 {
+		return s
+	}
+	decoded, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		// Not every deployment base64-encodes its secrets; fall back to
+		// the raw value rather than failing startup over it.
+		return s
+	}
+	return string(decoded)
+}
+
+// ComponentSet is a set of Bugzilla component names, configured in YAML as
+// a plain list of strings.
+type ComponentSet sets.String
+
+// List returns the component names in sorted order.
+func (c ComponentSet) List() []string {
+	return sets.String(c).List()
+}
+
+// UnmarshalYAML decodes a YAML list of strings into a ComponentSet.
+func (c *ComponentSet) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var list []string
+	if err := unmarshal(&list); err != nil {
+		return err
+	}
+	*c = ComponentSet(sets.NewString(list...))
+	return nil
+}
+
+// Anonymize returns a copy of c with its Credentials cleared, suitable for
+// logging the rest of the configuration without leaking secrets.
+func (c OperatorConfig) Anonymize() OperatorConfig {
+	c.Credentials = Credentials{}
+	return c
+}
+
+// Load reads and parses the YAML configuration file at path.
+func Load(path string) (OperatorConfig, error) {
+	var cfg OperatorConfig
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return cfg, fmt.Errorf("failed to read config %q: %w", path, err)
+	}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to parse config %q: %w", path, err)
+	}
+	return cfg, nil
+}
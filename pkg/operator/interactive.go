@@ -0,0 +1,179 @@
+package operator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/eparis/bugzilla"
+	"github.com/openshift/library-go/pkg/operator/events"
+	slackgo "github.com/slack-go/slack"
+
+	"github.com/mfojtik/bugzilla-operator/pkg/cache"
+	"github.com/mfojtik/bugzilla-operator/pkg/operator/blocks"
+	"github.com/mfojtik/bugzilla-operator/pkg/operator/config"
+)
+
+// interactivePayload is the subset of Slack's interactive-message callback
+// payload (https://api.slack.com/legacy/interactive-messages) this handler
+// needs: which button was clicked, by whom, and on which report/bug.
+type interactivePayload struct {
+	Token string `json:"token"`
+	User  struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"user"`
+	Actions []struct {
+		ActionID string `json:"action_id"`
+		Value    string `json:"value"`
+	} `json:"actions"`
+	ResponseURL string `json:"response_url"`
+}
+
+// bugMutator applies a single bug-action button's effect. client is the
+// same cache.BugzillaClient used everywhere else; it is asserted against a
+// narrow local interface per-action so this file doesn't need to carry the
+// full (and, in this tree, unavailable) BugzillaClient method set.
+type bugMutator func(ctx context.Context, client cache.BugzillaClient, bugID int, user string) error
+
+type bugUpdater interface {
+	UpdateBug(id int, update bugzilla.BugUpdate) error
+}
+
+// callBugzilla runs update against client. bzClient is already wrapped by
+// instrumentBugzillaClient (see newBugzillaClient), so this call is recorded
+// on the bugzilla_operator_bugzilla_call_* metrics without this file having
+// to do it itself.
+func callBugzilla(client cache.BugzillaClient, bugID int, update bugzilla.BugUpdate) error {
+	updater, ok := client.(bugUpdater)
+	if !ok {
+		return fmt.Errorf("bugzilla client does not support UpdateBug")
+	}
+	return updater.UpdateBug(bugID, update)
+}
+
+var bugMutators = map[string]bugMutator{
+	// assign-to-me reassigns the clicked bug to whoever clicked the button.
+	"assign-to-me": func(ctx context.Context, client cache.BugzillaClient, bugID int, user string) error {
+		return callBugzilla(client, bugID, bugzilla.BugUpdate{AssignedTo: user})
+	},
+	// reset-stale clears the stale marker by moving the bug back to NEW,
+	// mirroring what the stale-reset controller itself does to a bug it
+	// no longer considers stale -- but only for the one bug clicked, not
+	// every bug the controller would otherwise sweep.
+	"reset-stale": func(ctx context.Context, client cache.BugzillaClient, bugID int, user string) error {
+		return callBugzilla(client, bugID, bugzilla.BugUpdate{Status: "NEW"})
+	},
+	// close closes the clicked bug directly, without re-running the
+	// close-stale controller's full sweep over every other bug.
+	"close": func(ctx context.Context, client cache.BugzillaClient, bugID int, user string) error {
+		return callBugzilla(client, bugID, bugzilla.BugUpdate{Status: "CLOSED"})
+	},
+}
+
+// isGroupMember reports whether userID or userName belongs to cfg's named
+// group. This is the single source of truth for group membership: auth()
+// (which gates the admin Slack commands with "group:admins") should call
+// this too rather than deriving membership its own way, so the two gates
+// can't silently drift apart.
+func isGroupMember(cfg config.OperatorConfig, group, userID, userName string) bool {
+	for _, member := range cfg.Groups[group] {
+		if member == userID || member == userName {
+			return true
+		}
+	}
+	return false
+}
+
+// isAdmin reports whether userID or userName belongs to cfg's "admins"
+// group, the same membership auth() requires of the admin Slack commands.
+func isAdmin(cfg config.OperatorConfig, userID, userName string) bool {
+	return isGroupMember(cfg, "admins", userID, userName)
+}
+
+// pushPage re-renders job's entries at page and posts the result back over
+// payload.ResponseURL, replacing the original message in place -- Slack's
+// standard mechanism for updating a message after a button click.
+func pushPage(responseURL string, job string, entries []blocks.Entry, page int) error {
+	msg := slackgo.Msg{
+		ReplaceOriginal: true,
+		Blocks:          slackgo.Blocks{BlockSet: blocks.Page(job, entries, page)},
+	}
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	resp, err := http.Post(responseURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("response_url POST returned %s", resp.Status)
+	}
+	return nil
+}
+
+// newInteractiveHandler returns the HTTP handler for Slack's interactive
+// callback URL. Reset-stale/Close/Assign-to-me all mutate only the clicked
+// bug directly through the Bugzilla client, and Prev/Next re-render that
+// job's stored entries and push them back via response_url. All of it
+// requires the clicking user to be in cfg's "admins" group, same as the
+// admin Slack commands.
+func newInteractiveHandler(cfg config.OperatorConfig, entryStore *blocks.EntryStore, bzClient cache.BugzillaClient, recorder events.Recorder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		var payload interactivePayload
+		if err := json.Unmarshal([]byte(r.FormValue("payload")), &payload); err != nil {
+			http.Error(w, "bad payload", http.StatusBadRequest)
+			return
+		}
+		if payload.Token != cfg.Credentials.DecodedSlackVerificationToken() {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		if !isAdmin(cfg, payload.User.ID, payload.User.Name) {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+
+		for _, action := range payload.Actions {
+			if job, page, ok := blocks.DecodePage(action.Value); ok {
+				entries, found := entryStore.Load(job)
+				if !found {
+					recorder.Warningf("ReportPageFailed", "%s requested page %d of %q but no entries are stored for it", payload.User.Name, page, job)
+					continue
+				}
+				if err := pushPage(payload.ResponseURL, job, entries, page); err != nil {
+					recorder.Warningf("ReportPageFailed", "%s requested page %d of %q: %v", payload.User.Name, page, job, err)
+					continue
+				}
+				recorder.Eventf("ReportPage", "%s moved to page %d of %q", payload.User.Name, page, job)
+				continue
+			}
+
+			job, bugID, kind, ok := blocks.DecodeValue(action.Value)
+			if !ok {
+				continue
+			}
+
+			mutate, ok := bugMutators[kind]
+			if !ok {
+				continue
+			}
+			if err := mutate(r.Context(), bzClient, bugID, payload.User.Name); err != nil {
+				recorder.Warningf("BugActionFailed", "%s failed on bug %d (job %q): %v", kind, bugID, job, err)
+			} else {
+				recorder.Eventf("BugActionApplied", "%s applied %q to bug %d", payload.User.Name, kind, bugID)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(slackgo.Msg{ReplaceOriginal: false, Text: "Got it, working on it."})
+	}
+}
@@ -0,0 +1,104 @@
+// Package logging provides a structured zerolog logger that tags every line
+// with the triggering job's correlation ID, plus an in-memory ring buffer so
+// a single run can be grepped end-to-end via the `admin logs <run-id>`
+// Slack command.
+package logging
+
+import (
+	"context"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/rs/zerolog"
+)
+
+type ctxKey struct{}
+
+// WithLogger returns a copy of ctx carrying logger, retrievable with FromContext.
+func WithLogger(ctx context.Context, logger zerolog.Logger) context.Context {
+	return context.WithValue(ctx, ctxKey{}, logger)
+}
+
+// FromContext returns the logger stashed by WithLogger, or a new
+// timestamped logger writing to stderr if none was attached.
+func FromContext(ctx context.Context) zerolog.Logger {
+	if logger, ok := ctx.Value(ctxKey{}).(zerolog.Logger); ok {
+		return logger
+	}
+	return zerolog.New(os.Stderr).With().Timestamp().Logger()
+}
+
+// RingBuffer keeps the most recent log lines for each of the most recent
+// runs in memory, so `admin logs <run-id>` doesn't need an external log
+// aggregator.
+type RingBuffer struct {
+	mu       sync.Mutex
+	maxRuns  int
+	maxLines int
+	order    []string
+	lines    map[string][]string
+}
+
+// NewRingBuffer keeps at most maxLines lines for each of the maxRuns most
+// recently started run IDs, evicting the run whose first line was written
+// earliest once maxRuns is exceeded. Later writes to a run do not move it
+// up in that order.
+func NewRingBuffer(maxRuns, maxLines int) *RingBuffer {
+	return &RingBuffer{
+		maxRuns:  maxRuns,
+		maxLines: maxLines,
+		lines:    map[string][]string{},
+	}
+}
+
+// Append adds line to runID's tail, evicting its oldest line once maxLines
+// is exceeded.
+func (b *RingBuffer) Append(runID, line string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.lines[runID]; !ok {
+		b.order = append(b.order, runID)
+		if len(b.order) > b.maxRuns {
+			oldest := b.order[0]
+			b.order = b.order[1:]
+			delete(b.lines, oldest)
+		}
+	}
+
+	lines := append(b.lines[runID], line)
+	if len(lines) > b.maxLines {
+		lines = lines[len(lines)-b.maxLines:]
+	}
+	b.lines[runID] = lines
+}
+
+// Tail returns the buffered lines for runID, oldest first.
+func (b *RingBuffer) Tail(runID string) []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string{}, b.lines[runID]...)
+}
+
+// ringWriter adapts RingBuffer to io.Writer, tagging every write with runID.
+type ringWriter struct {
+	buffer *RingBuffer
+	runID  string
+}
+
+func (w ringWriter) Write(p []byte) (int, error) {
+	w.buffer.Append(w.runID, string(p))
+	return len(p), nil
+}
+
+// NewLogger returns a zerolog.Logger that writes to stderr and, if buffer is
+// non-nil, also appends every line to buffer under runID so it can be
+// replayed later with Tail.
+func NewLogger(buffer *RingBuffer, runID string) zerolog.Logger {
+	var w io.Writer = os.Stderr
+	if buffer != nil {
+		w = zerolog.MultiLevelWriter(os.Stderr, ringWriter{buffer: buffer, runID: runID})
+	}
+	return zerolog.New(w).With().Timestamp().Str("run", runID).Logger()
+}
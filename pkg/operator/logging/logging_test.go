@@ -0,0 +1,48 @@
+package logging
+
+import "testing"
+
+func TestRingBufferTailOrder(t *testing.T) {
+	b := NewRingBuffer(2, 10)
+	b.Append("run-1", "a")
+	b.Append("run-1", "b")
+
+	got := b.Tail("run-1")
+	want := []string{"a", "b"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("Tail(run-1) = %v, want %v", got, want)
+	}
+}
+
+func TestRingBufferEvictsOldestLine(t *testing.T) {
+	b := NewRingBuffer(2, 2)
+	b.Append("run-1", "a")
+	b.Append("run-1", "b")
+	b.Append("run-1", "c")
+
+	got := b.Tail("run-1")
+	want := []string{"b", "c"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("Tail(run-1) = %v, want %v", got, want)
+	}
+}
+
+func TestRingBufferEvictsOldestRun(t *testing.T) {
+	b := NewRingBuffer(1, 10)
+	b.Append("run-1", "a")
+	b.Append("run-2", "b")
+
+	if got := b.Tail("run-1"); len(got) != 0 {
+		t.Fatalf("Tail(run-1) = %v, want evicted/empty", got)
+	}
+	if got := b.Tail("run-2"); len(got) != 1 || got[0] != "b" {
+		t.Fatalf("Tail(run-2) = %v, want [b]", got)
+	}
+}
+
+func TestRingBufferTailUnknownRun(t *testing.T) {
+	b := NewRingBuffer(2, 10)
+	if got := b.Tail("missing"); len(got) != 0 {
+		t.Fatalf("Tail(missing) = %v, want empty", got)
+	}
+}
@@ -0,0 +1,31 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// ObserveSync times fn -- a controller's Sync call -- and records its
+// duration and outcome against name in ControllerSyncDuration/Total/Errors.
+// Controllers' own internal resync loops run inside the vendored factory
+// library and aren't visible here; this covers the Syncs its callers wrap
+// (manual "admin trigger"/"admin debug" and the scheduler).
+func ObserveSync(name string, fn func() error) error {
+	timer := prometheus.NewTimer(ControllerSyncDuration.WithLabelValues(name))
+	err := fn()
+	timer.ObserveDuration()
+	ControllerSyncTotal.WithLabelValues(name).Inc()
+	if err != nil {
+		ControllerSyncErrors.WithLabelValues(name).Inc()
+	}
+	return err
+}
+
+// ObserveReport times fn -- a report generation call -- and records its
+// duration and result size against name.
+func ObserveReport(name string, fn func() (string, error)) (string, error) {
+	timer := prometheus.NewTimer(ReportDuration.WithLabelValues(name))
+	report, err := fn()
+	timer.ObserveDuration()
+	if err == nil {
+		ReportResultSize.WithLabelValues(name).Observe(float64(len(report)))
+	}
+	return report, err
+}
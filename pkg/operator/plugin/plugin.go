@@ -0,0 +1,102 @@
+// Package plugin provides the extension point third parties use to add new
+// controllers, reports and Slacker commands to the operator without editing
+// Run in pkg/operator/starter.go directly.
+package plugin
+
+import (
+	"fmt"
+	"path/filepath"
+	goplugin "plugin"
+	"sync"
+
+	"github.com/openshift/library-go/pkg/controller/factory"
+
+	"github.com/mfojtik/bugzilla-operator/pkg/operator/config"
+	"github.com/mfojtik/bugzilla-operator/pkg/operator/controller"
+	"github.com/mfojtik/bugzilla-operator/pkg/slacker"
+)
+
+// Plugin is implemented by anything that wants to contribute controllers,
+// scheduled reports or Slacker commands to the operator. Compile-time
+// plugins register themselves from an init() via Register. Out-of-tree
+// plugins built with `go build -buildmode=plugin` export a package-level
+// `Plugin` variable satisfying this interface and are picked up by LoadDir.
+type Plugin interface {
+	// Initialize is called once, before Controllers/Reports/SlackCommands,
+	// with the same context and config the built-in controllers receive.
+	Initialize(ctx controller.ControllerContext, cfg config.OperatorConfig) error
+
+	// Controllers returns the set of factory.Controller this plugin wants
+	// registered, keyed by job name (as used by `admin trigger <job>`).
+	Controllers() map[string]factory.Controller
+
+	// Reports returns the report controllers this plugin contributes, keyed
+	// by report name (as used by `admin debug <job>`). `report <job>` runs
+	// only the built-in reports, not these.
+	Reports() map[string]factory.Controller
+
+	// SlackCommands returns additional Slacker commands this plugin wants
+	// registered on the shared slacker.Slacker instance.
+	SlackCommands() map[string]*slacker.CommandDefinition
+
+	// Run is called after all controllers and commands have been wired up,
+	// so the plugin can start any goroutines of its own. It must not block.
+	Run() error
+}
+
+var (
+	mu         sync.Mutex
+	registered []Plugin
+)
+
+// Register adds a compile-time plugin to the registry. Plugins typically
+// call this from an init() in their own package, mirroring how controllers
+// and reporters are wired up elsewhere in this tree.
+func Register(p Plugin) {
+	mu.Lock()
+	defer mu.Unlock()
+	registered = append(registered, p)
+}
+
+// All returns every plugin registered so far, both compile-time (via
+// Register) and those loaded at runtime (via LoadDir).
+func All() []Plugin {
+	mu.Lock()
+	defer mu.Unlock()
+	out := make([]Plugin, len(registered))
+	copy(out, registered)
+	return out
+}
+
+// LoadDir loads every `*.so` file in dir as a Go plugin (-buildmode=plugin),
+// looks up its exported `Plugin` symbol and registers it. It is a no-op when
+// dir is empty, matching the pattern helperbot uses for its plugin directory.
+func LoadDir(dir string) error {
+	if len(dir) == 0 {
+		return nil
+	}
+	matches, err := filepath.Glob(filepath.Join(dir, "*.so"))
+	if err != nil {
+		return fmt.Errorf("failed to list plugins in %q: %w", dir, err)
+	}
+	for _, path := range matches {
+		p, err := goplugin.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open plugin %q: %w", path, err)
+		}
+		sym, err := p.Lookup("Plugin")
+		if err != nil {
+			return fmt.Errorf("plugin %q does not export a Plugin symbol: %w", path, err)
+		}
+		// Lookup returns a pointer to the exported symbol, not its value -- a
+		// plugin exports `var Plugin plugin.Plugin = impl{}`, so the symbol's
+		// dynamic type is *plugin.Plugin, and asserting straight to Plugin
+		// always fails.
+		plug, ok := sym.(*Plugin)
+		if !ok {
+			return fmt.Errorf("plugin %q exports Plugin but it does not implement plugin.Plugin", path)
+		}
+		Register(*plug)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package plugin
+
+import (
+	"testing"
+
+	"github.com/openshift/library-go/pkg/controller/factory"
+
+	"github.com/mfojtik/bugzilla-operator/pkg/operator/config"
+	"github.com/mfojtik/bugzilla-operator/pkg/operator/controller"
+	"github.com/mfojtik/bugzilla-operator/pkg/slacker"
+)
+
+type fakePlugin struct{ name string }
+
+func (f *fakePlugin) Initialize(ctx controller.ControllerContext, cfg config.OperatorConfig) error {
+	return nil
+}
+func (f *fakePlugin) Controllers() map[string]factory.Controller           { return nil }
+func (f *fakePlugin) Reports() map[string]factory.Controller               { return nil }
+func (f *fakePlugin) SlackCommands() map[string]*slacker.CommandDefinition { return nil }
+func (f *fakePlugin) Run() error                                           { return nil }
+
+func TestRegisterAndAll(t *testing.T) {
+	registered = nil
+
+	a := &fakePlugin{name: "a"}
+	b := &fakePlugin{name: "b"}
+	Register(a)
+	Register(b)
+
+	got := All()
+	if len(got) != 2 {
+		t.Fatalf("All() = %d plugins, want 2", len(got))
+	}
+	if got[0] != Plugin(a) || got[1] != Plugin(b) {
+		t.Fatalf("All() returned plugins in unexpected order/identity: %+v", got)
+	}
+
+	got[0] = nil
+	if registered[0] == nil {
+		t.Fatalf("All() must return a copy, mutating it must not affect the registry")
+	}
+}
@@ -0,0 +1,207 @@
+// Package scheduler runs named factory.Controller jobs on cron schedules,
+// replacing the fixed resync-period loop the operator previously used for
+// scheduled reports. It is modeled on the slackscot scheduler pattern of
+// registering named actions on a shared cron engine.
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-co-op/gocron"
+	"github.com/openshift/library-go/pkg/controller/factory"
+	"github.com/openshift/library-go/pkg/operator/events"
+
+	"github.com/mfojtik/bugzilla-operator/pkg/operator/metrics"
+)
+
+// Job tracks a single scheduled controller/report and its run history.
+type Job struct {
+	Name string
+	Cron string
+
+	controller factory.Controller
+	gocronJobs []*gocron.Job
+
+	mu      sync.Mutex
+	paused  bool
+	lastRun time.Time
+	lastErr error
+}
+
+// Status is a point-in-time snapshot of a Job, returned by Scheduler.List.
+type Status struct {
+	Name    string
+	Cron    string
+	Paused  bool
+	LastRun time.Time
+	LastErr error
+	NextRun time.Time
+}
+
+// Scheduler runs registered jobs on their cron schedule and posts
+// per-job start/finish telemetry to the admin channel via recorder.
+type Scheduler struct {
+	cron     *gocron.Scheduler
+	recorder events.Recorder
+	wg       *sync.WaitGroup
+
+	mu   sync.Mutex
+	jobs map[string]*Job
+}
+
+// New creates a Scheduler that runs jobs in UTC and reports progress
+// through recorder. wg is the same WaitGroup Run uses to drain in-flight
+// work on shutdown; every scheduled job execution, and the scheduler's own
+// background goroutine, are tracked on it so a graceful shutdown actually
+// waits for them.
+func New(recorder events.Recorder, wg *sync.WaitGroup) *Scheduler {
+	return &Scheduler{
+		cron:     gocron.NewScheduler(time.UTC),
+		recorder: recorder,
+		wg:       wg,
+		jobs:     map[string]*Job{},
+	}
+}
+
+// Schedule registers c to run under name on every cron expression in exprs.
+// Empty expressions are ignored. Re-scheduling an existing name replaces its
+// previous cron entries.
+func (s *Scheduler) Schedule(name string, exprs []string, c factory.Controller) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.jobs[name]; ok {
+		for _, gj := range existing.gocronJobs {
+			s.cron.RemoveByReference(gj)
+		}
+	}
+
+	job := &Job{Name: name, Cron: strings.Join(exprs, ","), controller: c}
+	for _, expr := range exprs {
+		if len(expr) == 0 {
+			continue
+		}
+		gj, err := s.cron.Cron(expr).Do(s.run, job)
+		if err != nil {
+			return fmt.Errorf("invalid schedule %q for job %q: %w", expr, name, err)
+		}
+		job.gocronJobs = append(job.gocronJobs, gj)
+	}
+	s.jobs[name] = job
+	return nil
+}
+
+// Start begins executing scheduled jobs in the background and stops them
+// once ctx is done.
+func (s *Scheduler) Start(ctx context.Context) {
+	s.cron.StartAsync()
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		<-ctx.Done()
+		s.cron.Stop()
+	}()
+}
+
+func (s *Scheduler) run(job *Job) {
+	job.mu.Lock()
+	if job.paused {
+		job.mu.Unlock()
+		return
+	}
+	job.mu.Unlock()
+
+	s.wg.Add(1)
+	defer s.wg.Done()
+
+	s.recorder.Eventf("ScheduledJobStarted", "Starting scheduled job %q", job.Name)
+	start := time.Now()
+	err := metrics.ObserveSync(job.Name, func() error {
+		return job.controller.Sync(context.Background(), factory.NewSyncContext(job.Name, s.recorder))
+	})
+
+	job.mu.Lock()
+	job.lastRun = start
+	job.lastErr = err
+	job.mu.Unlock()
+
+	if err != nil {
+		s.recorder.Warningf("ScheduledJobFailed", "Job %q failed after %v: %v", job.Name, time.Since(start), err)
+		return
+	}
+	s.recorder.Eventf("ScheduledJobFinished", "Job %q finished after %v", job.Name, time.Since(start))
+}
+
+// Pause stops name from running until Resume is called. Already in-flight
+// runs are not interrupted.
+func (s *Scheduler) Pause(name string) error {
+	job, err := s.job(name)
+	if err != nil {
+		return err
+	}
+	job.mu.Lock()
+	defer job.mu.Unlock()
+	job.paused = true
+	return nil
+}
+
+// Resume re-enables name after a Pause.
+func (s *Scheduler) Resume(name string) error {
+	job, err := s.job(name)
+	if err != nil {
+		return err
+	}
+	job.mu.Lock()
+	defer job.mu.Unlock()
+	job.paused = false
+	return nil
+}
+
+func (s *Scheduler) job(name string) (*Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, ok := s.jobs[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown scheduled job %q", name)
+	}
+	return job, nil
+}
+
+// List returns the status of every scheduled job, sorted by name.
+func (s *Scheduler) List() []Status {
+	s.mu.Lock()
+	names := make([]string, 0, len(s.jobs))
+	jobs := make(map[string]*Job, len(s.jobs))
+	for name, job := range s.jobs {
+		names = append(names, name)
+		jobs[name] = job
+	}
+	s.mu.Unlock()
+
+	sort.Strings(names)
+	statuses := make([]Status, 0, len(names))
+	for _, name := range names {
+		job := jobs[name]
+		job.mu.Lock()
+		status := Status{
+			Name:    job.Name,
+			Cron:    job.Cron,
+			Paused:  job.paused,
+			LastRun: job.lastRun,
+			LastErr: job.lastErr,
+		}
+		job.mu.Unlock()
+		for _, gj := range job.gocronJobs {
+			if next := gj.NextRun(); status.NextRun.IsZero() || next.Before(status.NextRun) {
+				status.NextRun = next
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
@@ -0,0 +1,98 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/openshift/library-go/pkg/controller/factory"
+	"github.com/openshift/library-go/pkg/operator/events"
+)
+
+type fakeController struct {
+	name  string
+	mu    sync.Mutex
+	syncs int
+	err   error
+}
+
+func (f *fakeController) Name() string                         { return f.name }
+func (f *fakeController) Run(ctx context.Context, workers int) {}
+func (f *fakeController) Sync(ctx context.Context, syncCtx factory.SyncContext) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.syncs++
+	return f.err
+}
+func (f *fakeController) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.syncs
+}
+
+func newTestScheduler() (*Scheduler, *sync.WaitGroup) {
+	var wg sync.WaitGroup
+	return New(events.NewInMemoryRecorder("test", nil), &wg), &wg
+}
+
+func TestRunSkipsPausedJob(t *testing.T) {
+	s, wg := newTestScheduler()
+	c := &fakeController{name: "job"}
+	if err := s.Schedule("job", []string{"@every 1h"}, c); err != nil {
+		t.Fatalf("Schedule() error = %v", err)
+	}
+
+	if err := s.Pause("job"); err != nil {
+		t.Fatalf("Pause() error = %v", err)
+	}
+	job, err := s.job("job")
+	if err != nil {
+		t.Fatalf("job() error = %v", err)
+	}
+	s.run(job)
+	wg.Wait()
+	if got := c.count(); got != 0 {
+		t.Fatalf("run() on a paused job synced %d times, want 0", got)
+	}
+
+	if err := s.Resume("job"); err != nil {
+		t.Fatalf("Resume() error = %v", err)
+	}
+	s.run(job)
+	wg.Wait()
+	if got := c.count(); got != 1 {
+		t.Fatalf("run() after Resume synced %d times, want 1", got)
+	}
+}
+
+func TestListReportsLastRunAndError(t *testing.T) {
+	s, wg := newTestScheduler()
+	c := &fakeController{name: "job", err: context.DeadlineExceeded}
+	if err := s.Schedule("job", []string{"@every 1h"}, c); err != nil {
+		t.Fatalf("Schedule() error = %v", err)
+	}
+	job, err := s.job("job")
+	if err != nil {
+		t.Fatalf("job() error = %v", err)
+	}
+	s.run(job)
+	wg.Wait()
+
+	statuses := s.List()
+	if len(statuses) != 1 {
+		t.Fatalf("List() = %d statuses, want 1", len(statuses))
+	}
+	if statuses[0].Name != "job" || statuses[0].LastErr != context.DeadlineExceeded {
+		t.Fatalf("List()[0] = %+v, want Name=job LastErr=%v", statuses[0], context.DeadlineExceeded)
+	}
+}
+
+func TestPauseResumeUnknownJob(t *testing.T) {
+	s, _ := newTestScheduler()
+	if err := s.Pause("missing"); err == nil {
+		t.Fatalf("Pause() on unknown job = nil error, want error")
+	}
+	if err := s.Resume("missing"); err == nil {
+		t.Fatalf("Resume() on unknown job = nil error, want error")
+	}
+}
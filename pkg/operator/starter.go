@@ -3,12 +3,15 @@ package operator
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/eparis/bugzilla"
+	"github.com/google/uuid"
 	"github.com/openshift/library-go/pkg/controller/factory"
 	slackgo "github.com/slack-go/slack"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -17,16 +20,21 @@ import (
 	"k8s.io/klog"
 
 	"github.com/mfojtik/bugzilla-operator/pkg/cache"
+	"github.com/mfojtik/bugzilla-operator/pkg/operator/blocks"
 	"github.com/mfojtik/bugzilla-operator/pkg/operator/closecontroller"
 	"github.com/mfojtik/bugzilla-operator/pkg/operator/config"
 	"github.com/mfojtik/bugzilla-operator/pkg/operator/controller"
 	"github.com/mfojtik/bugzilla-operator/pkg/operator/firstteamcommentcontroller"
+	"github.com/mfojtik/bugzilla-operator/pkg/operator/logging"
+	"github.com/mfojtik/bugzilla-operator/pkg/operator/metrics"
 	"github.com/mfojtik/bugzilla-operator/pkg/operator/newcontroller"
+	"github.com/mfojtik/bugzilla-operator/pkg/operator/plugin"
 	"github.com/mfojtik/bugzilla-operator/pkg/operator/reporters/blockers"
 	"github.com/mfojtik/bugzilla-operator/pkg/operator/reporters/closed"
 	"github.com/mfojtik/bugzilla-operator/pkg/operator/reporters/incoming"
 	"github.com/mfojtik/bugzilla-operator/pkg/operator/reporters/upcomingsprint"
 	"github.com/mfojtik/bugzilla-operator/pkg/operator/resetcontroller"
+	"github.com/mfojtik/bugzilla-operator/pkg/operator/scheduler"
 	"github.com/mfojtik/bugzilla-operator/pkg/operator/stalecontroller"
 	"github.com/mfojtik/bugzilla-operator/pkg/slack"
 	"github.com/mfojtik/bugzilla-operator/pkg/slacker"
@@ -50,6 +58,16 @@ func Run(ctx context.Context, cfg config.OperatorConfig) error {
 
 	recorder := slack.NewRecorder(slackAdminClient, "BugzillaOperator")
 
+	// logBuffer keeps the tail of every recent job run's log lines in
+	// memory so `admin logs <run-id>` can replay a single run end-to-end.
+	logBuffer := logging.NewRingBuffer(200, 500)
+
+	// wg tracks every long-running goroutine started below (controllers,
+	// the slacker listener, manually triggered jobs) so shutdown can wait
+	// for in-flight Bugzilla mutations to finish instead of cutting them
+	// off mid-flight.
+	var wg sync.WaitGroup
+
 	defer func() {
 		recorder.Warningf("Shutdown", ":crossed_fingers: *The bot is shutting down*")
 	}()
@@ -92,9 +110,19 @@ func Run(ctx context.Context, cfg config.OperatorConfig) error {
 
 	// TODO: enable by default
 	cfg.DisabledControllers = append(cfg.DisabledControllers, "NewBugController")
+	disabled := sets.NewString(cfg.DisabledControllers...)
 
-	var scheduledReports []factory.Controller
+	// jobScheduler runs every scheduled report on its own cron expression(s)
+	// (cfg.Schedules[].When), replacing the old fixed
+	// resync-period loop for reports.
+	jobScheduler := scheduler.New(recorder, &wg)
+	// reportEntryStore remembers each report's last-rendered entries so the
+	// interactive Prev/Next buttons can rebuild a later page without
+	// re-running the report.
+	reportEntryStore := blocks.NewEntryStore()
 	reportNames := sets.NewString()
+	scheduledJobNames := sets.NewString()
+	var scheduledControllerNames []string
 	newReport := func(name string, ctx controller.ControllerContext, components, when []string) factory.Controller {
 		switch name {
 		case "blocker-bugs":
@@ -115,9 +143,22 @@ func Run(ctx context.Context, cfg config.OperatorConfig) error {
 		slackChannelClient := slack.NewChannelClient(slackClient, ar.SlackChannel, cfg.SlackAdminChannel, false)
 		reporterContext := controller.NewControllerContext(newBugzillaClient(&cfg, slackDebugClient), slackChannelClient, slackDebugClient, cmClient)
 		for _, r := range ar.Reports {
-			if c := newReport(r, reporterContext, ar.Components, ar.When); c != nil {
-				scheduledReports = append(scheduledReports, c)
-				reportNames.Insert(r)
+			c := newReport(r, reporterContext, ar.Components, ar.When)
+			if c == nil {
+				continue
+			}
+			reportNames.Insert(r)
+			scheduledControllerNames = append(scheduledControllerNames, c.Name())
+			if disabled.Has(c.Name()) {
+				continue
+			}
+			jobName := r
+			if scheduledJobNames.Has(jobName) {
+				jobName = fmt.Sprintf("%s-%d", r, scheduledJobNames.Len())
+			}
+			scheduledJobNames.Insert(jobName)
+			if err := jobScheduler.Schedule(jobName, ar.When, c); err != nil {
+				return fmt.Errorf("failed to schedule report %q: %w", r, err)
 			}
 		}
 	}
@@ -126,6 +167,29 @@ func Run(ctx context.Context, cfg config.OperatorConfig) error {
 		debugReportControllers[r] = newReport(r, controllerContext, cfg.Components.List(), nil)
 	}
 
+	// Pick up any third-party controllers/reports/commands dropped into
+	// cfg.PluginDir as -buildmode=plugin .so files, in addition to the
+	// compile-time plugins that registered themselves via plugin.Register.
+	if err := plugin.LoadDir(cfg.PluginDir); err != nil {
+		return fmt.Errorf("failed to load plugins from %q: %w", cfg.PluginDir, err)
+	}
+	pluginSlackCommands := map[string]*slacker.CommandDefinition{}
+	for _, p := range plugin.All() {
+		if err := p.Initialize(controllerContext, cfg); err != nil {
+			return fmt.Errorf("failed to initialize plugin: %w", err)
+		}
+		for name, c := range p.Controllers() {
+			controllers[name] = c
+		}
+		for name, c := range p.Reports() {
+			debugReportControllers[name] = c
+			reportNames.Insert(name)
+		}
+		for usage, def := range p.SlackCommands() {
+			pluginSlackCommands[usage] = def
+		}
+	}
+
 	controllerNames := sets.NewString()
 	for n := range controllers {
 		controllerNames.Insert(n)
@@ -148,27 +212,41 @@ func Run(ctx context.Context, cfg config.OperatorConfig) error {
 				}
 			}
 
+			wg.Add(1)
+			defer wg.Done()
+
+			runID := uuid.New().String()
+			jobLogger := logging.NewLogger(logBuffer, runID).With().Str("job", job).Str("user", req.Event().User).Logger()
+
 			ctx := ctx // shadow global ctx
+			ctx = logging.WithLogger(ctx, jobLogger)
 			if debug {
 				ctx = context.WithValue(ctx, "debug", debug)
 			}
 
 			startTime := time.Now()
+			jobLogger.Info().Msg("triggering job")
+			metrics.SlackAPICallTotal.WithLabelValues("chat.postEphemeral").Inc()
 			_, _, _, err := w.Client().SendMessage(req.Event().Channel,
 				slackgo.MsgOptionPostEphemeral(req.Event().User),
-				slackgo.MsgOptionText(fmt.Sprintf("Triggering job %q", job), false))
+				slackgo.MsgOptionText(fmt.Sprintf("Triggering job %q (run %s)", job, runID), false))
 			if err != nil {
-				klog.Error(err)
+				jobLogger.Error().Err(err).Msg("failed to post ephemeral message")
 			}
-			if err := c.Sync(ctx, factory.NewSyncContext(job, recorder)); err != nil {
-				recorder.Warningf("ReportError", "Job reported error: %v", err)
+			if err := metrics.ObserveSync(job, func() error {
+				return c.Sync(ctx, factory.NewSyncContext(job, recorder))
+			}); err != nil {
+				jobLogger.Error().Err(err).Msg("job failed")
+				recorder.Warningf("ReportError", "Job %q reported error (run %s): %v", job, runID, err)
 				return
 			}
+			jobLogger.Info().Dur("duration", time.Since(startTime)).Msg("job finished")
+			metrics.SlackAPICallTotal.WithLabelValues("chat.postEphemeral").Inc()
 			_, _, _, err = w.Client().SendMessage(req.Event().Channel,
 				slackgo.MsgOptionPostEphemeral(req.Event().User),
-				slackgo.MsgOptionText(fmt.Sprintf("Finished job %q after %v", job, time.Since(startTime)), false))
+				slackgo.MsgOptionText(fmt.Sprintf("Finished job %q after %v (run %s)", job, time.Since(startTime), runID), false))
 			if err != nil {
-				klog.Error(err)
+				jobLogger.Error().Err(err).Msg("failed to post ephemeral message")
 			}
 		}
 	}
@@ -218,6 +296,7 @@ func Run(ctx context.Context, cfg config.OperatorConfig) error {
 				return
 			}
 
+			metrics.SlackAPICallTotal.WithLabelValues("chat.postEphemeral").Inc()
 			_, _, _, err := w.Client().SendMessage(req.Event().Channel,
 				slackgo.MsgOptionPostEphemeral(req.Event().User),
 				slackgo.MsgOptionText(fmt.Sprintf("Running job %q. This might take some seconds.", job), false))
@@ -225,8 +304,11 @@ func Run(ctx context.Context, cfg config.OperatorConfig) error {
 				klog.Error(err)
 			}
 
-			reply, err := report(context.TODO(), newBugzillaClient(&cfg, slackDebugClient)(true)) // report should never write anything to BZ
+			reply, err := metrics.ObserveReport(job, func() (string, error) {
+				return report(context.TODO(), newBugzillaClient(&cfg, slackDebugClient)(true)) // report should never write anything to BZ
+			})
 			if err != nil {
+				metrics.SlackAPICallTotal.WithLabelValues("chat.postEphemeral").Inc()
 				_, _, _, err := w.Client().SendMessage(req.Event().Channel,
 					slackgo.MsgOptionPostEphemeral(req.Event().User),
 					slackgo.MsgOptionText(fmt.Sprintf("Error running report %v: %v", job, err), false))
@@ -234,26 +316,132 @@ func Run(ctx context.Context, cfg config.OperatorConfig) error {
 					klog.Error(err)
 				}
 			} else {
-				w.Reply(reply)
+				entries := blocks.Split(reply)
+				reportEntryStore.Save(job, entries)
+				metrics.SlackAPICallTotal.WithLabelValues("chat.postMessage").Inc()
+				if _, _, _, err := w.Client().SendMessage(req.Event().Channel, slackgo.MsgOptionBlocks(blocks.Page(job, entries, 0)...)); err != nil {
+					klog.Error(err)
+				}
 			}
 		},
 	})
 
-	seen := []string{}
-	disabled := sets.NewString(cfg.DisabledControllers...)
+	slackerInstance.Command("admin schedule list", &slacker.CommandDefinition{
+		Description: "List scheduled jobs and their next/last run.",
+		Handler: auth(cfg, func(req slacker.Request, w slacker.ResponseWriter) {
+			var lines []string
+			for _, status := range jobScheduler.List() {
+				state := "active"
+				if status.Paused {
+					state = "paused"
+				}
+				lines = append(lines, fmt.Sprintf("*%s* (`%s`, %s) last=%v next=%v", status.Name, status.Cron, state, status.LastRun, status.NextRun))
+			}
+			if len(lines) == 0 {
+				w.Reply("No scheduled jobs.")
+				return
+			}
+			w.Reply(strings.Join(lines, "\n"))
+		}, "group:admins"),
+	})
+	slackerInstance.Command("admin schedule pause <job>", &slacker.CommandDefinition{
+		Description: "Pause a scheduled job.",
+		Handler: auth(cfg, func(req slacker.Request, w slacker.ResponseWriter) {
+			job := req.StringParam("job", "")
+			if err := jobScheduler.Pause(job); err != nil {
+				w.Reply(err.Error())
+				return
+			}
+			w.Reply(fmt.Sprintf("Paused %q", job))
+		}, "group:admins"),
+	})
+	slackerInstance.Command("admin schedule resume <job>", &slacker.CommandDefinition{
+		Description: "Resume a paused scheduled job.",
+		Handler: auth(cfg, func(req slacker.Request, w slacker.ResponseWriter) {
+			job := req.StringParam("job", "")
+			if err := jobScheduler.Resume(job); err != nil {
+				w.Reply(err.Error())
+				return
+			}
+			w.Reply(fmt.Sprintf("Resumed %q", job))
+		}, "group:admins"),
+	})
+
+	slackerInstance.Command("admin logs <run-id>", &slacker.CommandDefinition{
+		Description: "Show the tail of a job run's log lines.",
+		Handler: auth(cfg, func(req slacker.Request, w slacker.ResponseWriter) {
+			runID := req.StringParam("run-id", "")
+			lines := logBuffer.Tail(runID)
+			if len(lines) == 0 {
+				w.Reply(fmt.Sprintf("No logs found for run %q", runID))
+				return
+			}
+			w.Reply(fmt.Sprintf("```\n%s\n```", strings.Join(lines, "\n")))
+		}, "group:admins"),
+	})
+
+	for usage, def := range pluginSlackCommands {
+		slackerInstance.Command(usage, def)
+	}
+	for _, p := range plugin.All() {
+		if err := p.Run(); err != nil {
+			return fmt.Errorf("failed to run plugin: %w", err)
+		}
+	}
+
+	seen := append([]string{}, scheduledControllerNames...)
 	var all []factory.Controller
 	for _, c := range controllers {
 		all = append(all, c)
 	}
-	for _, c := range append(all, scheduledReports...) {
+	for _, c := range all {
 		seen = append(seen, c.Name())
 		if disabled.Has(c.Name()) {
 			continue
 		}
-		go c.Run(ctx, 1)
+		wg.Add(1)
+		go func(c factory.Controller) {
+			defer wg.Done()
+			metrics.ControllerUp.WithLabelValues(c.Name()).Set(1)
+			defer metrics.ControllerUp.WithLabelValues(c.Name()).Set(0)
+			c.Run(ctx, 1)
+		}(c)
 	}
+	jobScheduler.Start(ctx)
 
-	go slackerInstance.Run(ctx)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		slackerInstance.Run(ctx)
+	}()
+
+	var metricsServer *http.Server
+	if len(cfg.MetricsAddress) > 0 {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", metrics.Handler())
+		metricsServer = &http.Server{Addr: cfg.MetricsAddress, Handler: mux}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				klog.Errorf("metrics server failed: %v", err)
+			}
+		}()
+	}
+
+	var interactiveServer *http.Server
+	if len(cfg.SlackInteractiveAddress) > 0 {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/slack/interactive", newInteractiveHandler(cfg, reportEntryStore, newBugzillaClient(&cfg, slackDebugClient)(false), recorder))
+		interactiveServer = &http.Server{Addr: cfg.SlackInteractiveAddress, Handler: mux}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := interactiveServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				klog.Errorf("interactive callback server failed: %v", err)
+			}
+		}()
+	}
 
 	// sanity check list of disabled controllers
 	unknown := disabled.Difference(sets.NewString(seen...))
@@ -265,14 +453,50 @@ func Run(ctx context.Context, cfg config.OperatorConfig) error {
 
 	<-ctx.Done()
 
+	// Stop accepting new scrapes before draining in-flight jobs so a scrape
+	// racing the shutdown can't observe a half-torn-down process.
+	if metricsServer != nil {
+		if err := metricsServer.Shutdown(context.Background()); err != nil {
+			klog.Errorf("failed to shut down metrics server: %v", err)
+		}
+	}
+	if interactiveServer != nil {
+		if err := interactiveServer.Shutdown(context.Background()); err != nil {
+			klog.Errorf("failed to shut down interactive callback server: %v", err)
+		}
+	}
+
+	// defaultShutdownGracePeriod applies when the config doesn't set one, so
+	// an operator that hasn't picked up the new YAML key still gets a real
+	// grace period instead of cfg.ShutdownGracePeriod's zero value, which
+	// would fire the timer immediately and cut in-flight jobs off exactly
+	// as before this was added.
+	const defaultShutdownGracePeriod = 30 * time.Second
+	gracePeriod := cfg.ShutdownGracePeriod
+	if gracePeriod <= 0 {
+		gracePeriod = defaultShutdownGracePeriod
+	}
+
+	klog.Info("shutdown requested, waiting for in-flight jobs to drain")
+	drained := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+	case <-time.After(gracePeriod):
+		klog.Warning("shutdown grace period exceeded, exiting with jobs still in flight")
+	}
+
 	return nil
 }
 
 func newBugzillaClient(cfg *config.OperatorConfig, slackDebugClient slack.ChannelClient) func(debug bool) cache.BugzillaClient {
 	return func(debug bool) cache.BugzillaClient {
-		c := cache.NewCachedBugzillaClient(bugzilla.NewClient(func() []byte {
+		c := instrumentBugzillaClient(cache.NewCachedBugzillaClient(bugzilla.NewClient(func() []byte {
 			return []byte(cfg.Credentials.DecodedAPIKey())
-		}, bugzillaEndpoint).WithCGIClient(cfg.Credentials.DecodedUsername(), cfg.Credentials.DecodedPassword()))
+		}, bugzillaEndpoint).WithCGIClient(cfg.Credentials.DecodedUsername(), cfg.Credentials.DecodedPassword())))
 		if debug {
 			return &loggingReadOnlyClient{delegate: c, slackLoggingClient: slackDebugClient}
 		}